@@ -0,0 +1,170 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docmodel defines the machine-readable command documentation schema
+// shared by the structured doc generators (yamldoc, jsondoc), and the small
+// set of cobra-tree helpers (HasSeeAlso, SortByName) shared across every
+// doc generator in this tree (rest, man, mdx, yamldoc, jsondoc) so they
+// don't each carry their own copy.
+package docmodel
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// SchemaVersion is bumped whenever CommandDoc's shape changes in a
+// backwards-incompatible way. Consumers should check it before relying on
+// new or renamed fields.
+const SchemaVersion = 1
+
+// CommandDoc is a machine-readable description of a single cobra command.
+type CommandDoc struct {
+	SchemaVersion    int          `json:"schema_version" yaml:"schema_version"`
+	Name             string       `json:"name" yaml:"name"`
+	Synopsis         string       `json:"synopsis" yaml:"synopsis"`
+	Description      string       `json:"description" yaml:"description"`
+	Usage            string       `json:"usage" yaml:"usage"`
+	Examples         []string     `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Options          []OptionDoc  `json:"options,omitempty" yaml:"options,omitempty"`
+	InheritedOptions []OptionDoc  `json:"inherited_options,omitempty" yaml:"inherited_options,omitempty"`
+	Subcommands      []CommandRef `json:"subcommands,omitempty" yaml:"subcommands,omitempty"`
+	SeeAlso          []CommandRef `json:"see_also,omitempty" yaml:"see_also,omitempty"`
+}
+
+// OptionDoc describes a single command-line flag.
+type OptionDoc struct {
+	Name        string `json:"name" yaml:"name"`
+	Shorthand   string `json:"shorthand,omitempty" yaml:"shorthand,omitempty"`
+	Type        string `json:"type" yaml:"type"`
+	Default     string `json:"default,omitempty" yaml:"default,omitempty"`
+	Description string `json:"description" yaml:"description"`
+	Required    bool   `json:"required" yaml:"required"`
+	Deprecated  bool   `json:"deprecated" yaml:"deprecated"`
+}
+
+// CommandRef is a named pointer to another command document.
+type CommandRef struct {
+	Name string `json:"name" yaml:"name"`
+	Ref  string `json:"ref" yaml:"ref"`
+}
+
+// Build converts a cobra command into its CommandDoc representation.
+func Build(cmd *cobra.Command) CommandDoc {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	name := cmd.CommandPath()
+	long := cmd.Long
+	if long == "" {
+		long = cmd.Short
+	}
+
+	doc := CommandDoc{
+		SchemaVersion:    SchemaVersion,
+		Name:             name,
+		Synopsis:         cmd.Short,
+		Description:      long,
+		Usage:            strings.ReplaceAll(cmd.UseLine(), "[flags]", "[options]"),
+		Options:          buildOptions(cmd.NonInheritedFlags()),
+		InheritedOptions: buildOptions(cmd.InheritedFlags()),
+	}
+
+	if len(cmd.Example) > 0 {
+		doc.Examples = strings.Split(strings.TrimRight(cmd.Example, "\n"), "\n")
+	}
+
+	children := cmd.Commands()
+	SortByName(children)
+	for _, child := range children {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		cname := name + " " + child.Name()
+		doc.Subcommands = append(doc.Subcommands, CommandRef{Name: cname, Ref: strings.ReplaceAll(cname, " ", "_")})
+	}
+
+	if cmd.HasParent() {
+		parent := cmd.Parent()
+		pname := parent.CommandPath()
+		doc.SeeAlso = append(doc.SeeAlso, CommandRef{Name: pname, Ref: strings.ReplaceAll(pname, " ", "_")})
+	}
+	for _, child := range children {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		cname := name + " " + child.Name()
+		doc.SeeAlso = append(doc.SeeAlso, CommandRef{Name: cname, Ref: strings.ReplaceAll(cname, " ", "_")})
+	}
+
+	return doc
+}
+
+func buildOptions(flags *pflag.FlagSet) []OptionDoc {
+	if !flags.HasAvailableFlags() {
+		return nil
+	}
+	var opts []OptionDoc
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		opts = append(opts, OptionDoc{
+			Name:        f.Name,
+			Shorthand:   f.Shorthand,
+			Type:        f.Value.Type(),
+			Default:     f.DefValue,
+			Description: f.Usage,
+			Required:    isRequired(f),
+			Deprecated:  f.Deprecated != "",
+		})
+	})
+	return opts
+}
+
+func isRequired(f *pflag.Flag) bool {
+	_, ok := f.Annotations[cobra.BashCompOneRequiredFlag]
+	return ok
+}
+
+// HasSeeAlso tests whether there's a reason to print "See Also" information
+// in docs: a parent command, or a subcommand which is both not deprecated
+// and not the autogenerated help command.
+func HasSeeAlso(cmd *cobra.Command) bool {
+	if cmd.HasParent() {
+		return true
+	}
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// SortByName sorts commands alphabetically by name, in place, the order
+// every doc generator in this tree renders subcommand/see-also listings in.
+func SortByName(cmds []*cobra.Command) {
+	sort.Sort(byName(cmds))
+}
+
+type byName []*cobra.Command
+
+func (s byName) Len() int           { return len(s) }
+func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byName) Less(i, j int) bool { return s[i].Name() < s[j].Name() }
@@ -0,0 +1,49 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsondoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mongodb/mongocli/internal/docs/docmodel"
+	"github.com/spf13/cobra"
+)
+
+func TestGenJSON(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a cluster",
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	cmd.Flags().String("name", "", "Cluster name")
+
+	buf := new(bytes.Buffer)
+	if err := GenJSON(cmd, buf); err != nil {
+		t.Fatalf("GenJSON returned an error: %v", err)
+	}
+
+	var doc docmodel.CommandDoc
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if doc.SchemaVersion != docmodel.SchemaVersion {
+		t.Errorf("expected schema_version %d, got %d", docmodel.SchemaVersion, doc.SchemaVersion)
+	}
+	if doc.Name != "create" {
+		t.Errorf("expected name %q, got %q", "create", doc.Name)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package man was mostly inspired by github.com/spf13/cobra/doc
+// but with some changes to match the expected formats and styles of our writers and tools.
+package man
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mongodb/mongocli/internal/docs/docmodel"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GenManHeader is a lightweight representation of man page header details.
+type GenManHeader struct {
+	Title   string
+	Section string
+	Source  string
+	Manual  string
+	Date    *time.Time
+}
+
+// GenManTree generates a man page for the command and all descendants
+// in the directory given, using the defaults GenManHeader.
+func GenManTree(cmd *cobra.Command, header *GenManHeader, dir string) error {
+	return GenManTreeFromOpts(cmd, GenManTreeOptions{
+		Header:           header,
+		Path:             dir,
+		CommandSeparator: "-",
+	})
+}
+
+// GenManTreeOptions is the options for GenManTreeFromOpts.
+type GenManTreeOptions struct {
+	Header           *GenManHeader
+	Path             string
+	CommandSeparator string
+	// Filename, when set, overrides the default mongocli-a-b-c.1 naming scheme.
+	Filename func(cmd *cobra.Command, opts GenManTreeOptions) string
+}
+
+// GenManTreeFromOpts generates a man page for the command and all descendants.
+// The pages are written to the opts.Path directory.
+func GenManTreeFromOpts(cmd *cobra.Command, opts GenManTreeOptions) error {
+	header := opts.Header
+	if header == nil {
+		header = &GenManHeader{}
+	}
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		childHeader := *header
+		if err := GenManTreeFromOpts(c, GenManTreeOptions{
+			Header:           &childHeader,
+			Path:             opts.Path,
+			CommandSeparator: opts.CommandSeparator,
+			Filename:         opts.Filename,
+		}); err != nil {
+			return err
+		}
+	}
+
+	basename := opts.Filename
+	if basename == nil {
+		basename = defaultFilename
+	}
+	filename := filepath.Join(opts.Path, basename(cmd, opts))
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	headerCopy := *header
+	return GenMan(cmd, &headerCopy, f)
+}
+
+func defaultFilename(cmd *cobra.Command, opts GenManTreeOptions) string {
+	sep := opts.CommandSeparator
+	if sep == "" {
+		sep = "-"
+	}
+	section := "1"
+	if opts.Header != nil && opts.Header.Section != "" {
+		section = opts.Header.Section
+	}
+	base := strings.ReplaceAll(cmd.CommandPath(), " ", sep)
+	return fmt.Sprintf("%s.%s", base, section)
+}
+
+// GenMan generates a man page for the given command and writes it to w.
+// Adapted from github.com/spf13/cobra/doc to match MongoDB CLI tooling and style.
+func GenMan(cmd *cobra.Command, header *GenManHeader, w io.Writer) error {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	if header == nil {
+		header = &GenManHeader{}
+	}
+	fillHeader(header, cmd.CommandPath())
+
+	buf := new(bytes.Buffer)
+	if err := genManTroff(cmd, header, buf); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func fillHeader(header *GenManHeader, name string) {
+	if header.Title == "" {
+		header.Title = strings.ToUpper(strings.ReplaceAll(name, " ", "-"))
+	}
+	if header.Section == "" {
+		header.Section = "1"
+	}
+	if header.Source == "" {
+		header.Source = "MongoDB CLI"
+	}
+	if header.Manual == "" {
+		header.Manual = "MongoDB CLI Manual"
+	}
+	if header.Date == nil {
+		now := time.Now()
+		header.Date = &now
+	}
+}
+
+func genManTroff(cmd *cobra.Command, header *GenManHeader, buf *bytes.Buffer) error {
+	name := cmd.CommandPath()
+
+	fmt.Fprintf(buf, `.TH "%s" "%s" "%s" "%s" "%s"
+`, header.Title, header.Section, header.Date.Format("Jan 2006"), header.Source, header.Manual)
+
+	buf.WriteString(".SH NAME\n")
+	fmt.Fprintf(buf, "%s \\- %s\n\n", name, cmd.Short)
+
+	buf.WriteString(".SH SYNOPSIS\n")
+	if cmd.Runnable() {
+		fmt.Fprintf(buf, ".B %s\n", strings.ReplaceAll(cmd.UseLine(), "[flags]", "[options]"))
+	}
+	buf.WriteString("\n")
+
+	long := cmd.Long
+	if long == "" {
+		long = cmd.Short
+	}
+	buf.WriteString(".SH DESCRIPTION\n")
+	buf.WriteString(md2troff(long))
+	buf.WriteString("\n")
+
+	if err := printOptionsTroff(buf, cmd); err != nil {
+		return err
+	}
+
+	if len(cmd.Example) > 0 {
+		buf.WriteString(".SH EXAMPLE\n")
+		buf.WriteString(".PP\n.RS\n\n.nf\n")
+		buf.WriteString(cmd.Example)
+		buf.WriteString("\n.fi\n.RE\n\n")
+	}
+
+	if docmodel.HasSeeAlso(cmd) {
+		buf.WriteString(".SH SEE ALSO\n")
+		seeAlsos := []string{}
+		if cmd.HasParent() {
+			parent := cmd.Parent()
+			seeAlsos = append(seeAlsos, fmt.Sprintf("%s(%s)", strings.ReplaceAll(parent.CommandPath(), " ", "-"), header.Section))
+		}
+		children := cmd.Commands()
+		docmodel.SortByName(children)
+		for _, child := range children {
+			if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+				continue
+			}
+			seeAlsos = append(seeAlsos, fmt.Sprintf("%s-%s(%s)", strings.ReplaceAll(name, " ", "-"), child.Name(), header.Section))
+		}
+		buf.WriteString(strings.Join(seeAlsos, ", "))
+		buf.WriteString("\n")
+	}
+
+	return nil
+}
+
+func printOptionsTroff(buf *bytes.Buffer, cmd *cobra.Command) error {
+	flags := cmd.NonInheritedFlags()
+	if flags.HasAvailableFlags() {
+		buf.WriteString(".SH OPTIONS\n")
+		flags.VisitAll(func(f *pflag.Flag) {
+			writeFlagTroff(buf, f)
+		})
+		buf.WriteString("\n")
+	}
+
+	parentFlags := cmd.InheritedFlags()
+	if parentFlags.HasAvailableFlags() {
+		buf.WriteString(".SH OPTIONS INHERITED FROM PARENT COMMANDS\n")
+		parentFlags.VisitAll(func(f *pflag.Flag) {
+			writeFlagTroff(buf, f)
+		})
+		buf.WriteString("\n")
+	}
+	return nil
+}
+
+func writeFlagTroff(buf *bytes.Buffer, f *pflag.Flag) {
+	fmt.Fprintf(buf, ".TP\n.B \\-\\-%s", f.Name)
+	if f.Shorthand != "" {
+		fmt.Fprintf(buf, ", \\-%s", f.Shorthand)
+	}
+	buf.WriteString("\n")
+	buf.WriteString(f.Usage)
+	buf.WriteString("\n")
+}
+
+// md2troff performs a minimal markdown-to-troff conversion, covering the
+// handful of constructs that show up in cobra Long descriptions: paragraph
+// breaks, `code spans`, **bold** and *italic*/_italic_ emphasis, plus the
+// escaping go-md2man itself relies on (backslashes, hyphens, and a leading
+// "." or "'" that troff would otherwise treat as a macro request). It does
+// not aim to be a full CommonMark implementation.
+func md2troff(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			out = append(out, ".PP")
+			continue
+		}
+		out = append(out, troffEscape(line))
+	}
+	return strings.Join(out, "\n")
+}
+
+var (
+	codeSpanRE = regexp.MustCompile("`([^`]+)`")
+	boldRE     = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	italicRE   = regexp.MustCompile(`\*([^*]+)\*|_([^_]+)_`)
+)
+
+func troffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+
+	// Code spans are pulled out behind placeholders before the bold/italic
+	// passes run, so a span like `a*b*` isn't re-matched by italicRE and
+	// mangled into a malformed nested font escape (\fBa\fIb\fR\fR).
+	var spans []string
+	s = codeSpanRE.ReplaceAllStringFunc(s, func(m string) string {
+		groups := codeSpanRE.FindStringSubmatch(m)
+		spans = append(spans, `\fB`+groups[1]+`\fR`)
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+
+	s = boldRE.ReplaceAllString(s, `\fB$1\fR`)
+	s = italicRE.ReplaceAllStringFunc(s, func(m string) string {
+		groups := italicRE.FindStringSubmatch(m)
+		text := groups[1]
+		if text == "" {
+			text = groups[2]
+		}
+		return `\fI` + text + `\fR`
+	})
+
+	for i, span := range spans {
+		s = strings.ReplaceAll(s, fmt.Sprintf("\x00%d\x00", i), span)
+	}
+
+	// A line starting with "." or "'" would otherwise be parsed by troff as
+	// a macro request rather than text (e.g. a Long description line like
+	// ".env files are supported."); "\&" is a zero-width escape that forces
+	// it to be read literally, mirroring go-md2man's own guard.
+	if len(s) > 0 && (s[0] == '.' || s[0] == '\'') {
+		s = `\&` + s
+	}
+	return s
+}
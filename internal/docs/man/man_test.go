@@ -0,0 +1,39 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package man
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTroffEscapeCodeSpanWithAsterisks(t *testing.T) {
+	got := troffEscape("see `a*b*` for details")
+	if strings.Contains(got, `\fI`) {
+		t.Fatalf("asterisks inside a code span should not be re-escaped as italic, got %q", got)
+	}
+	want := `see \fBa*b*\fR for details`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestTroffEscapeBoldAndItalic(t *testing.T) {
+	got := troffEscape("this is **bold** and *italic*")
+	want := `this is \fBbold\fR and \fIitalic\fR`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
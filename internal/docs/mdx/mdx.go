@@ -0,0 +1,212 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mdx was mostly inspired by github.com/spf13/cobra/doc and our own
+// internal/docs/rest package, but emits MDX for docs sites built on
+// Docusaurus/Nextra instead of Snooty-oriented reST.
+package mdx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mongodb/mongocli/internal/docs/docmodel"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GenMDXTree generates MDX docs for the full tree of commands.
+func GenMDXTree(cmd *cobra.Command, dir string) error {
+	emptyStr := func(s string) string { return "" }
+	return GenMDXCustom(cmd, dir, emptyStr, defaultLinkHandler, defaultSlugifier)
+}
+
+// GenMDXCustom is the same as GenMDXTree, but with a custom filePrepender,
+// linkHandler and slugifier.
+func GenMDXCustom(cmd *cobra.Command, dir string, filePrepender func(string) string, linkHandler func(string, string) string, slugifier func(string) string) error {
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if err := GenMDXCustom(c, dir, filePrepender, linkHandler, slugifier); err != nil {
+			return err
+		}
+	}
+
+	basename := strings.ReplaceAll(cmd.CommandPath(), " ", "-") + ".mdx"
+	filename := filepath.Join(dir, basename)
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.WriteString(f, filePrepender(filename)); err != nil {
+		return err
+	}
+	return GenMDX(cmd, f, linkHandler, slugifier)
+}
+
+// GenMDX creates custom MDX output.
+func GenMDX(cmd *cobra.Command, w io.Writer, linkHandler func(string, string) string, slugifier func(string) string) error {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	buf := new(bytes.Buffer)
+	name := cmd.CommandPath()
+	slug := slugifier(name)
+
+	short := cmd.Short
+	long := cmd.Long
+	if long == "" {
+		long = short
+	}
+
+	writeFrontmatter(buf, name, short, slug, depth(cmd))
+
+	buf.WriteString("# " + name + "\n\n")
+	buf.WriteString(escapeMDX(long) + "\n\n")
+
+	if cmd.Runnable() {
+		buf.WriteString("```bash\n")
+		buf.WriteString(strings.ReplaceAll(cmd.UseLine(), "[flags]", "[options]") + "\n")
+		buf.WriteString("```\n\n")
+	}
+
+	printOptionsMDX(buf, cmd)
+
+	if len(cmd.Example) > 0 {
+		buf.WriteString("## Examples\n\n")
+		buf.WriteString("```bash\n")
+		buf.WriteString(strings.TrimRight(cmd.Example, "\n") + "\n")
+		buf.WriteString("```\n\n")
+	}
+
+	if docmodel.HasSeeAlso(cmd) {
+		buf.WriteString("## See Also\n\n")
+		if cmd.HasParent() {
+			parent := cmd.Parent()
+			pname := parent.CommandPath()
+			buf.WriteString(fmt.Sprintf("* %s - %s\n", linkHandler(pname, slugifier(pname)), escapeMDX(parent.Short)))
+		}
+
+		children := cmd.Commands()
+		docmodel.SortByName(children)
+
+		for _, child := range children {
+			if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+				continue
+			}
+			cname := name + " " + child.Name()
+			buf.WriteString(fmt.Sprintf("* %s - %s\n", linkHandler(cname, slugifier(cname)), escapeMDX(child.Short)))
+		}
+		buf.WriteString("\n")
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func writeFrontmatter(buf *bytes.Buffer, name, short, slug string, sidebarPosition int) {
+	buf.WriteString("---\n")
+	buf.WriteString("title: " + strconv.Quote(name) + "\n")
+	buf.WriteString("slug: " + strconv.Quote(slug) + "\n")
+	buf.WriteString("description: " + strconv.Quote(short) + "\n")
+	buf.WriteString("sidebar_position: " + strconv.Itoa(sidebarPosition) + "\n")
+	buf.WriteString("---\n\n")
+}
+
+func depth(cmd *cobra.Command) int {
+	d := 0
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		d++
+	}
+	return d
+}
+
+func printOptionsMDX(buf *bytes.Buffer, cmd *cobra.Command) {
+	flags := cmd.NonInheritedFlags()
+	if flags.HasAvailableFlags() {
+		buf.WriteString("## Options\n\n")
+		buf.WriteString("| Option | Type | Description | Required |\n")
+		buf.WriteString("| --- | --- | --- | --- |\n")
+		buf.WriteString(flagRows(flags))
+		buf.WriteString("\n")
+	}
+
+	parentFlags := cmd.InheritedFlags()
+	if parentFlags.HasAvailableFlags() {
+		buf.WriteString("## Inherited Options\n\n")
+		buf.WriteString("| Option | Type | Description | Required |\n")
+		buf.WriteString("| --- | --- | --- | --- |\n")
+		buf.WriteString(flagRows(parentFlags))
+		buf.WriteString("\n")
+	}
+}
+
+func flagRows(flags *pflag.FlagSet) string {
+	var b strings.Builder
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		name := "--" + f.Name
+		if f.Shorthand != "" {
+			name = "-" + f.Shorthand + ", " + name
+		}
+		required := ""
+		if _, ok := f.Annotations[cobra.BashCompOneRequiredFlag]; ok {
+			required = "true"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", name, f.Value.Type(), escapeMDXCell(f.Usage), required)
+	})
+	return b.String()
+}
+
+// escapeMDX escapes characters MDX would otherwise try to parse as JSX
+// (curly-brace expressions, angle-bracket tags) when they appear in prose
+// pulled verbatim from cobra Short/Long strings.
+func escapeMDX(s string) string {
+	replacer := strings.NewReplacer(
+		"{", `\{`,
+		"}", `\}`,
+		"<", `\<`,
+		">", `\>`,
+	)
+	return replacer.Replace(s)
+}
+
+// escapeMDXCell is escapeMDX plus pipe-escaping, for text embedded in a
+// Markdown table cell, where an unescaped "|" would otherwise split into
+// extra columns.
+func escapeMDXCell(s string) string {
+	return escapeMDX(strings.ReplaceAll(s, "|", `\|`))
+}
+
+// defaultLinkHandler for default MDX hyperlink markup, linking to a sibling
+// page by its slug.
+func defaultLinkHandler(name, slug string) string {
+	return fmt.Sprintf("[%s](%s)", name, slug)
+}
+
+// defaultSlugifier mirrors the default filename scheme used by GenTree,
+// producing slugs like /mongocli-atlas-clusters-create.
+func defaultSlugifier(name string) string {
+	return "/" + strings.ReplaceAll(name, " ", "-")
+}
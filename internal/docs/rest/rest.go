@@ -18,15 +18,20 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/mongodb/mongocli/internal/docs/docmodel"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // GenTree generates the docs for the full tree of commands
@@ -38,35 +43,164 @@ func GenTree(cmd *cobra.Command, dir string) error {
 // GenTreeCustom is the the same as GenTree, but
 // with custom filePrepender and linkHandler.
 func GenTreeCustom(cmd *cobra.Command, dir string, filePrepender func(string) string, linkHandler func(string, string) string) error {
+	return GenTreeWithOptions(cmd, dir, filePrepender, linkHandler, GenTreeOptions{})
+}
+
+// GenTreeOptions configures GenTreeWithOptions.
+type GenTreeOptions struct {
+	// Concurrency is the number of commands rendered in parallel. Zero
+	// means runtime.GOMAXPROCS(0).
+	Concurrency int
+}
+
+// genTreeItem is a single command queued for rendering, flattened out of
+// the command tree so it can be handed to a worker pool.
+type genTreeItem struct {
+	cmd      *cobra.Command
+	filename string
+}
+
+// GenTreeWithOptions is the same as GenTreeCustom, but renders the tree with
+// a worker pool sized by opts.Concurrency instead of walking it serially.
+// The first rendering error cancels the remaining work.
+func GenTreeWithOptions(cmd *cobra.Command, dir string, filePrepender func(string) string, linkHandler func(string, string) string, opts GenTreeOptions) error {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	warmFlagCaches(cmd)
+	items := flattenTree(cmd, dir, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	work := make(chan genTreeItem)
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				if err := renderTreeItem(item, filePrepender, linkHandler); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			break feed
+		case work <- item:
+		}
+	}
+	close(work)
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return err
+	}
+	return nil
+}
+
+// warmFlagCaches forces cobra to materialize the lazily-built flag sets
+// (NonInheritedFlags/InheritedFlags merge a command's own flags with its
+// ancestors' persistent flags the first time either is called, mutating
+// fields on the command itself and on every ancestor up to the root) for
+// the whole tree, serially, before GenTreeWithOptions fans rendering out to
+// a worker pool. Without this, concurrent renders of a command and one of
+// its ancestors race on that shared, lazily-initialized state.
+func warmFlagCaches(cmd *cobra.Command) {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+	cmd.NonInheritedFlags()
+	cmd.InheritedFlags()
+	for _, c := range cmd.Commands() {
+		warmFlagCaches(c)
+	}
+}
+
+// flattenTree walks cmd's descendants depth-first, collecting one
+// genTreeItem per renderable command so GenTreeWithOptions can fan the work
+// out to a worker pool instead of recursing serially.
+func flattenTree(cmd *cobra.Command, dir string, items []genTreeItem) []genTreeItem {
 	for _, c := range cmd.Commands() {
 		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
 			continue
 		}
-		if err := GenTreeCustom(c, dir, filePrepender, linkHandler); err != nil {
-			return err
-		}
+		items = flattenTree(c, dir, items)
 	}
 
 	basename := strings.ReplaceAll(cmd.CommandPath(), " ", "-") + ".txt"
-	filename := filepath.Join(dir, basename)
-	f, err := os.Create(filename)
-	if err != nil {
+	items = append(items, genTreeItem{cmd: cmd, filename: filepath.Join(dir, basename)})
+	return items
+}
+
+// renderTreeItem renders a single command into a buffer and writes it to
+// disk atomically (render to a temp file, then rename), so a crash or
+// cancellation never leaves a partially written doc behind.
+func renderTreeItem(item genTreeItem, filePrepender func(string) string, linkHandler func(string, string) string) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString(filePrepender(item.filename))
+	if err := GenCustom(item.cmd, buf, linkHandler); err != nil {
 		return err
 	}
-	defer f.Close()
 
-	if _, err := io.WriteString(f, filePrepender(filename)); err != nil {
+	tmp, err := os.CreateTemp(filepath.Dir(item.filename), ".tmp-*")
+	if err != nil {
 		return err
 	}
-	if err := GenCustom(cmd, f, linkHandler); err != nil {
+	tmpName := tmp.Name()
+	if _, err := buf.WriteTo(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
 		return err
 	}
-	return nil
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, item.filename)
+}
+
+// Now is the clock GenCustom uses to stamp the auto-generated footer. Tests
+// and callers that need reproducible output can override it.
+var Now = time.Now
+
+// GenOptions configures GenCustomWithOptions.
+type GenOptions struct {
+	// OmitAutoGenTag suppresses the "Auto generated by MongoDB CLI on ..."
+	// footer entirely, for reproducible-build workflows where a regenerated
+	// file must not churn on every run.
+	OmitAutoGenTag bool
+	// Clock overrides Now for this call. Ignored when a SOURCE_DATE_EPOCH
+	// environment variable holds a valid integer Unix timestamp (a value
+	// that does not parse is ignored instead), per the Reproducible Builds
+	// convention (https://reproducible-builds.org/specs/source-date-epoch/).
+	Clock func() time.Time
 }
 
 // GenCustom creates custom reStructured Text output.
 // Adapted from github.com/spf13/cobra/doc to match MongoDB tooling and style
 func GenCustom(cmd *cobra.Command, w io.Writer, linkHandler func(string, string) string) error {
+	return GenCustomWithOptions(cmd, w, linkHandler, GenOptions{})
+}
+
+// GenCustomWithOptions is the same as GenCustom, but lets callers omit the
+// timestamped footer or supply a fixed clock so output is byte-for-byte
+// reproducible across runs.
+func GenCustomWithOptions(cmd *cobra.Command, w io.Writer, linkHandler func(string, string) string, opts GenOptions) error {
 	cmd.InitDefaultHelpCmd()
 	cmd.InitDefaultHelpFlag()
 
@@ -87,6 +221,8 @@ func GenCustom(cmd *cobra.Command, w io.Writer, linkHandler func(string, string)
 	buf.WriteString(short + "\n")
 	buf.WriteString("\n" + long + "\n\n")
 
+	writeVersionDirectives(buf, cmd.Annotations, cmd.Deprecated)
+
 	if cmd.Runnable() {
 		buf.WriteString(fmt.Sprintf(".. code-block::\n\n   %s\n\n", strings.ReplaceAll(cmd.UseLine(), "[flags]", "[options]")))
 	}
@@ -101,7 +237,7 @@ func GenCustom(cmd *cobra.Command, w io.Writer, linkHandler func(string, string)
 		buf.WriteString(fmt.Sprintf(".. code-block::\n%s\n\n", indentString(cmd.Example, " ")))
 	}
 
-	if hasSeeAlso(cmd) {
+	if docmodel.HasSeeAlso(cmd) {
 		buf.WriteString("See Also\n")
 		buf.WriteString("~~~~~~~~\n\n")
 		if cmd.HasParent() {
@@ -109,15 +245,10 @@ func GenCustom(cmd *cobra.Command, w io.Writer, linkHandler func(string, string)
 			pname := parent.CommandPath()
 			ref = strings.ReplaceAll(pname, " ", "_")
 			buf.WriteString(fmt.Sprintf("* %s \t - %s\n", linkHandler(pname, ref), parent.Short))
-			cmd.VisitParents(func(c *cobra.Command) {
-				if c.DisableAutoGenTag {
-					cmd.DisableAutoGenTag = c.DisableAutoGenTag
-				}
-			})
 		}
 
 		children := cmd.Commands()
-		sort.Sort(byName(children))
+		docmodel.SortByName(children)
 
 		for _, child := range children {
 			if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
@@ -129,34 +260,39 @@ func GenCustom(cmd *cobra.Command, w io.Writer, linkHandler func(string, string)
 		}
 		buf.WriteString("\n")
 	}
-	if !cmd.DisableAutoGenTag {
-		buf.WriteString("*Auto generated by MongoDB CLI on " + time.Now().Format("2-Jan-2006") + "*\n")
+	if !cmd.DisableAutoGenTag && !opts.OmitAutoGenTag {
+		buf.WriteString("*Auto generated by MongoDB CLI on " + genTimestamp(opts).Format("2-Jan-2006") + "*\n")
 	}
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-// Test to see if we have a reason to print See Also information in docs
-// Basically this is a test for a parent command or a subcommand which is
-// both not deprecated and not the autogenerated help command.
-func hasSeeAlso(cmd *cobra.Command) bool {
-	if cmd.HasParent() {
-		return true
+// genTimestamp resolves the clock used for the auto-generated footer: an
+// explicit opts.Clock, falling back to Now, but deferring to
+// SOURCE_DATE_EPOCH when it is set to a valid integer so reproducible-build pipelines get a fixed
+// timestamp without every caller having to wire that through.
+func genTimestamp(opts GenOptions) time.Time {
+	if epoch, ok := sourceDateEpoch(); ok {
+		return epoch
 	}
-	for _, c := range cmd.Commands() {
-		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
-			continue
-		}
-		return true
+	clock := opts.Clock
+	if clock == nil {
+		clock = Now
 	}
-	return false
+	return clock()
 }
 
-type byName []*cobra.Command
-
-func (s byName) Len() int           { return len(s) }
-func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s byName) Less(i, j int) bool { return s[i].Name() < s[j].Name() }
+func sourceDateEpoch() (time.Time, bool) {
+	v := os.Getenv("SOURCE_DATE_EPOCH")
+	if v == "" {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).UTC(), true
+}
 
 func printOptionsReST(buf *bytes.Buffer, cmd *cobra.Command) error {
 	flags := cmd.NonInheritedFlags()
@@ -172,6 +308,7 @@ func printOptionsReST(buf *bytes.Buffer, cmd *cobra.Command) error {
      - Required
 `)
 		buf.WriteString(indentString(FlagUsages(flags), " "))
+		buf.WriteString(indentString(flagVersionRows(flags), " "))
 		buf.WriteString("\n")
 	}
 
@@ -188,11 +325,88 @@ func printOptionsReST(buf *bytes.Buffer, cmd *cobra.Command) error {
      - Required
 `)
 		buf.WriteString(indentString(FlagUsages(parentFlags), " "))
+		buf.WriteString(indentString(flagVersionRows(parentFlags), " "))
 		buf.WriteString("\n")
 	}
 	return nil
 }
 
+// flagVersionRows renders the version-history admonitions for any flag that
+// carries versionAdded/versionChanged/deprecatedIn annotations, or that is
+// marked deprecated, as extra rows appended under the options table. Flags
+// with none of these are skipped entirely so unaffected docs don't churn.
+func flagVersionRows(flags *pflag.FlagSet) string {
+	var b strings.Builder
+	flags.VisitAll(func(f *pflag.Flag) {
+		directives := versionDirectives(flagAnnotations(f), f.Deprecated)
+		if directives == "" {
+			return
+		}
+		fmt.Fprintf(&b, "   * - --%s\n     -\n     - %s\n     -\n", f.Name, strings.ReplaceAll(directives, "\n", " "))
+	})
+	return b.String()
+}
+
+// flagAnnotations flattens a pflag.Flag's Annotations (map[string][]string,
+// since pflag allows multi-valued annotations) down to the single string per
+// key that versionDirectives expects, taking the first value set.
+func flagAnnotations(f *pflag.Flag) map[string]string {
+	if len(f.Annotations) == 0 {
+		return nil
+	}
+	annotations := make(map[string]string, len(f.Annotations))
+	for k, v := range f.Annotations {
+		if len(v) > 0 {
+			annotations[k] = v[0]
+		}
+	}
+	return annotations
+}
+
+// writeVersionDirectives renders versionadded/versionchanged/deprecated
+// Sphinx admonitions read from annotations (as set via the versionAdded,
+// versionChanged and deprecatedIn cobra Annotations keys) plus an explicit
+// deprecation message (from cmd.Deprecated/flag.Deprecated), which is
+// otherwise silently ignored by this package.
+func writeVersionDirectives(buf *bytes.Buffer, annotations map[string]string, deprecated string) {
+	directives := versionDirectives(annotations, deprecated)
+	if directives == "" {
+		return
+	}
+	buf.WriteString(directives)
+	buf.WriteString("\n")
+}
+
+const (
+	annotationVersionAdded  = "versionAdded"
+	annotationVersionChange = "versionChanged"
+	annotationDeprecatedIn  = "deprecatedIn"
+)
+
+// versionDirectives builds the raw Sphinx admonition text for a command or
+// flag from its annotations and deprecation message. It returns "" when
+// there is nothing to report.
+func versionDirectives(annotations map[string]string, deprecated string) string {
+	var b strings.Builder
+	if v := annotations[annotationVersionAdded]; v != "" {
+		fmt.Fprintf(&b, ".. versionadded:: %s\n\n", v)
+	}
+	if v := annotations[annotationVersionChange]; v != "" {
+		fmt.Fprintf(&b, ".. versionchanged:: %s\n\n", v)
+	}
+	if v, msg := annotations[annotationDeprecatedIn], deprecated; v != "" || msg != "" {
+		switch {
+		case v != "" && msg != "":
+			fmt.Fprintf(&b, ".. deprecated:: %s %s\n\n", v, msg)
+		case v != "":
+			fmt.Fprintf(&b, ".. deprecated:: %s\n\n", v)
+		default:
+			fmt.Fprintf(&b, ".. deprecated:: %s\n\n", msg)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
 // linkHandler for default ReST hyperlink markup
 func defaultLinkHandler(name, ref string) string {
 	return fmt.Sprintf("`%s <%s.txt>`_", name, ref)
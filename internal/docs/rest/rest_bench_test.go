@@ -0,0 +1,69 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newBenchTree builds a synthetic command tree with 611 commands (the root,
+// 10 top-level groups, each with 10 subcommands, each with 5 leaves) to
+// exercise GenTreeWithOptions at a scale comparable to mongocli's own
+// command tree.
+func newBenchTree() *cobra.Command {
+	root := &cobra.Command{Use: "mongocli", Short: "root"}
+	for i := 0; i < 10; i++ {
+		group := &cobra.Command{Use: fmt.Sprintf("group%d", i), Short: "group"}
+		for j := 0; j < 10; j++ {
+			sub := &cobra.Command{Use: fmt.Sprintf("sub%d", j), Short: "sub"}
+			for k := 0; k < 5; k++ {
+				leaf := &cobra.Command{
+					Use:   fmt.Sprintf("leaf%d", k),
+					Short: "leaf",
+					Run:   func(cmd *cobra.Command, args []string) {},
+				}
+				sub.AddCommand(leaf)
+			}
+			group.AddCommand(sub)
+		}
+		root.AddCommand(group)
+	}
+	return root
+}
+
+func BenchmarkGenTreeWithOptions(b *testing.B) {
+	emptyStr := func(s string) string { return "" }
+	for i := 0; i < b.N; i++ {
+		root := newBenchTree()
+		dir := b.TempDir()
+		if err := GenTreeWithOptions(root, dir, emptyStr, defaultLinkHandler, GenTreeOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenTreeWithOptionsSerial(b *testing.B) {
+	emptyStr := func(s string) string { return "" }
+	for i := 0; i < b.N; i++ {
+		root := newBenchTree()
+		dir := b.TempDir()
+		if err := GenTreeWithOptions(root, dir, emptyStr, defaultLinkHandler, GenTreeOptions{Concurrency: 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yamldoc emits one YAML document per command describing its
+// synopsis, options and relationships, for consumers that need structured
+// data rather than our reST docs (docs sites, IDE plugins, autocompletion
+// generators, LSPs). The layout mirrors cobra's own yaml_docs.go so existing
+// consumers can be pointed at mongocli output with minimal changes.
+package yamldoc
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongodb/mongocli/internal/docs/docmodel"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// GenYAMLTree generates YAML docs for the full tree of commands.
+func GenYAMLTree(cmd *cobra.Command, dir string) error {
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if err := GenYAMLTree(c, dir); err != nil {
+			return err
+		}
+	}
+
+	basename := strings.ReplaceAll(cmd.CommandPath(), " ", "-") + ".yaml"
+	filename := filepath.Join(dir, basename)
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return GenYAML(cmd, f)
+}
+
+// GenYAML writes the YAML representation of cmd to w.
+func GenYAML(cmd *cobra.Command, w io.Writer) error {
+	doc := docmodel.Build(cmd)
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	enc.SetIndent(2)
+	return enc.Encode(doc)
+}
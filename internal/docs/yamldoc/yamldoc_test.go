@@ -0,0 +1,45 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamldoc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestGenYAML(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a cluster",
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	cmd.Flags().String("name", "", "Cluster name")
+
+	buf := new(bytes.Buffer)
+	if err := GenYAML(cmd, buf); err != nil {
+		t.Fatalf("GenYAML returned an error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "schema_version: 1") {
+		t.Errorf("expected output to contain schema_version, got: %s", out)
+	}
+	if !strings.Contains(out, "name: create") {
+		t.Errorf("expected output to contain the command name, got: %s", out)
+	}
+}